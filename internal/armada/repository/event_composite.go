@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/G-Research/armada/pkg/api"
+
+// CompositeEventStore reports every event to each of its delegate stores in
+// turn, so that e.g. a NatsEventStore can be added alongside the existing
+// Redis-backed EventStore without either one needing to know about the
+// other.
+type CompositeEventStore struct {
+	delegates []EventStore
+}
+
+func NewCompositeEventStore(delegates ...EventStore) *CompositeEventStore {
+	return &CompositeEventStore{delegates: delegates}
+}
+
+func (eventStore *CompositeEventStore) ReportEvents(messages []*api.EventMessage) error {
+	for _, delegate := range eventStore.delegates {
+		if e := delegate.ReportEvents(messages); e != nil {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/G-Research/armada/pkg/api"
+)
+
+// NatsEventStore publishes the same events Armada writes to its Redis
+// event streams onto a NATS subject, so external systems can subscribe to
+// job lifecycle events without tailing Redis directly.
+type NatsEventStore struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func NewNatsEventStore(conn *nats.Conn, subject string) *NatsEventStore {
+	return &NatsEventStore{conn: conn, subject: subject}
+}
+
+func (eventStore *NatsEventStore) ReportEvents(messages []*api.EventMessage) error {
+	for _, message := range messages {
+		data, e := json.Marshal(message)
+		if e != nil {
+			return e
+		}
+		if e := eventStore.conn.Publish(eventStore.subject, data); e != nil {
+			return e
+		}
+	}
+	return nil
+}
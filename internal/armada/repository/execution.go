@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+)
+
+// TaskStatus is the lifecycle state of a single Task within an Execution.
+type TaskStatus string
+
+const (
+	TaskPending   TaskStatus = "Pending"
+	TaskRunning   TaskStatus = "Running"
+	TaskSucceeded TaskStatus = "Succeeded"
+	TaskFailed    TaskStatus = "Failed"
+	TaskSkipped   TaskStatus = "Skipped"
+)
+
+// Task is one unit of work within an Execution, e.g. cancelling a single
+// job as part of a bulk cancel-by-job-set operation.
+type Task struct {
+	Id          string
+	ExecutionId string
+	Status      TaskStatus
+	StartTime   time.Time
+	EndTime     time.Time
+	Error       string
+}
+
+// ExecutionStatus is the aggregate lifecycle state of an Execution.
+type ExecutionStatus string
+
+const (
+	ExecutionRunning   ExecutionStatus = "Running"
+	ExecutionStopping  ExecutionStatus = "Stopping"
+	ExecutionCompleted ExecutionStatus = "Completed"
+	ExecutionStopped   ExecutionStatus = "Stopped"
+)
+
+// Execution aggregates the many Tasks that make up one long-running admin
+// operation (a bulk cancel, a queue GC run, ...) so that it can be started
+// asynchronously and its progress polled rather than blocking the RPC that
+// kicked it off.
+type Execution struct {
+	Id        string
+	Kind      string
+	Operator  string
+	Status    ExecutionStatus
+	StartTime time.Time
+	EndTime   time.Time
+	TaskIds   []string
+}
+
+// ExecutionRepository persists Executions and their Tasks, along with the
+// lines of each Task's log, so that progress on a long-running admin
+// operation survives the lifetime of the goroutine driving it.
+type ExecutionRepository interface {
+	CreateExecution(kind string, operator string) (*Execution, error)
+	AddTask(executionId string, taskId string) error
+	UpdateTaskStatus(taskId string, status TaskStatus, taskError error) error
+	CompleteExecution(executionId string) error
+	StopExecution(executionId string) error
+	GetExecution(executionId string) (*Execution, error)
+	ListExecutions(kind string, status ExecutionStatus) ([]*Execution, error)
+	AppendTaskLog(taskId string, line string) error
+	GetTaskLog(taskId string) ([]string, error)
+}
+
+// InMemoryExecutionRepository is a simple, process-local ExecutionRepository.
+// It is sufficient for a single armada-server instance; a Redis-backed
+// implementation can be swapped in later if executions need to survive a
+// restart or be visible across replicas.
+type InMemoryExecutionRepository struct {
+	mutex      chan struct{}
+	executions map[string]*Execution
+	tasks      map[string]*Task
+	taskLogs   map[string][]string
+	nextId     int64
+}
+
+func NewInMemoryExecutionRepository() *InMemoryExecutionRepository {
+	repo := &InMemoryExecutionRepository{
+		mutex:      make(chan struct{}, 1),
+		executions: map[string]*Execution{},
+		tasks:      map[string]*Task{},
+		taskLogs:   map[string][]string{},
+	}
+	repo.mutex <- struct{}{}
+	return repo
+}
+
+func (repo *InMemoryExecutionRepository) lock() {
+	<-repo.mutex
+}
+
+func (repo *InMemoryExecutionRepository) unlock() {
+	repo.mutex <- struct{}{}
+}
+
+func (repo *InMemoryExecutionRepository) CreateExecution(kind string, operator string) (*Execution, error) {
+	repo.lock()
+	defer repo.unlock()
+
+	repo.nextId++
+	execution := &Execution{
+		Id:        fmt.Sprintf("execution-%d", repo.nextId),
+		Kind:      kind,
+		Operator:  operator,
+		Status:    ExecutionRunning,
+		StartTime: time.Now(),
+	}
+	repo.executions[execution.Id] = execution
+	return execution, nil
+}
+
+func (repo *InMemoryExecutionRepository) AddTask(executionId string, taskId string) error {
+	repo.lock()
+	defer repo.unlock()
+
+	execution, ok := repo.executions[executionId]
+	if !ok {
+		return fmt.Errorf("no such execution %s", executionId)
+	}
+	execution.TaskIds = append(execution.TaskIds, taskId)
+	repo.tasks[taskId] = &Task{Id: taskId, ExecutionId: executionId, Status: TaskPending, StartTime: time.Now()}
+	return nil
+}
+
+func (repo *InMemoryExecutionRepository) UpdateTaskStatus(taskId string, status TaskStatus, taskError error) error {
+	repo.lock()
+	defer repo.unlock()
+
+	task, ok := repo.tasks[taskId]
+	if !ok {
+		return fmt.Errorf("no such task %s", taskId)
+	}
+	task.Status = status
+	if status == TaskSucceeded || status == TaskFailed || status == TaskSkipped {
+		task.EndTime = time.Now()
+	}
+	if taskError != nil {
+		task.Error = taskError.Error()
+	}
+	return nil
+}
+
+// CompleteExecution marks an execution as finished. An execution that was
+// asked to stop finalizes as Stopped rather than Completed, so a caller can
+// tell a clean finish from one cut short.
+func (repo *InMemoryExecutionRepository) CompleteExecution(executionId string) error {
+	repo.lock()
+	defer repo.unlock()
+
+	execution, ok := repo.executions[executionId]
+	if !ok {
+		return fmt.Errorf("no such execution %s", executionId)
+	}
+	if execution.Status == ExecutionStopping {
+		execution.Status = ExecutionStopped
+	} else {
+		execution.Status = ExecutionCompleted
+	}
+	execution.EndTime = time.Now()
+	return nil
+}
+
+func (repo *InMemoryExecutionRepository) StopExecution(executionId string) error {
+	repo.lock()
+	defer repo.unlock()
+
+	execution, ok := repo.executions[executionId]
+	if !ok {
+		return fmt.Errorf("no such execution %s", executionId)
+	}
+	execution.Status = ExecutionStopping
+	return nil
+}
+
+func (repo *InMemoryExecutionRepository) GetExecution(executionId string) (*Execution, error) {
+	repo.lock()
+	defer repo.unlock()
+
+	execution, ok := repo.executions[executionId]
+	if !ok {
+		return nil, fmt.Errorf("no such execution %s", executionId)
+	}
+	return execution, nil
+}
+
+func (repo *InMemoryExecutionRepository) ListExecutions(kind string, status ExecutionStatus) ([]*Execution, error) {
+	repo.lock()
+	defer repo.unlock()
+
+	executions := make([]*Execution, 0)
+	for _, execution := range repo.executions {
+		if kind != "" && execution.Kind != kind {
+			continue
+		}
+		if status != "" && execution.Status != status {
+			continue
+		}
+		executions = append(executions, execution)
+	}
+	return executions, nil
+}
+
+func (repo *InMemoryExecutionRepository) AppendTaskLog(taskId string, line string) error {
+	repo.lock()
+	defer repo.unlock()
+
+	repo.taskLogs[taskId] = append(repo.taskLogs[taskId], line)
+	return nil
+}
+
+func (repo *InMemoryExecutionRepository) GetTaskLog(taskId string) ([]string, error) {
+	repo.lock()
+	defer repo.unlock()
+
+	return repo.taskLogs[taskId], nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import "testing"
+
+func TestInMemoryExecutionRepository_TaskLifecycle(t *testing.T) {
+	repo := NewInMemoryExecutionRepository()
+
+	execution, e := repo.CreateExecution("CancelJobSet", "test-user")
+	if e != nil {
+		t.Fatalf("CreateExecution returned error: %s", e.Error())
+	}
+	if execution.Status != ExecutionRunning {
+		t.Fatalf("expected new execution to be Running, got %s", execution.Status)
+	}
+
+	taskId := "task-1"
+	if e := repo.AddTask(execution.Id, taskId); e != nil {
+		t.Fatalf("AddTask returned error: %s", e.Error())
+	}
+
+	task, ok := repo.tasks[taskId]
+	if !ok || task.Status != TaskPending {
+		t.Fatalf("expected newly added task to be Pending, got %+v", task)
+	}
+
+	if e := repo.UpdateTaskStatus(taskId, TaskRunning, nil); e != nil {
+		t.Fatalf("UpdateTaskStatus returned error: %s", e.Error())
+	}
+	if repo.tasks[taskId].Status != TaskRunning {
+		t.Fatalf("expected task to be Running, got %s", repo.tasks[taskId].Status)
+	}
+
+	if e := repo.UpdateTaskStatus(taskId, TaskSucceeded, nil); e != nil {
+		t.Fatalf("UpdateTaskStatus returned error: %s", e.Error())
+	}
+	if repo.tasks[taskId].Status != TaskSucceeded {
+		t.Fatalf("expected task to be Succeeded, got %s", repo.tasks[taskId].Status)
+	}
+	if repo.tasks[taskId].EndTime.IsZero() {
+		t.Fatalf("expected EndTime to be set for a terminal task status")
+	}
+
+	if e := repo.CompleteExecution(execution.Id); e != nil {
+		t.Fatalf("CompleteExecution returned error: %s", e.Error())
+	}
+	completed, e := repo.GetExecution(execution.Id)
+	if e != nil {
+		t.Fatalf("GetExecution returned error: %s", e.Error())
+	}
+	if completed.Status != ExecutionCompleted {
+		t.Fatalf("expected execution to be Completed, got %s", completed.Status)
+	}
+}
+
+func TestInMemoryExecutionRepository_StopExecution(t *testing.T) {
+	repo := NewInMemoryExecutionRepository()
+
+	execution, e := repo.CreateExecution("CancelJobSet", "test-user")
+	if e != nil {
+		t.Fatalf("CreateExecution returned error: %s", e.Error())
+	}
+
+	if e := repo.StopExecution(execution.Id); e != nil {
+		t.Fatalf("StopExecution returned error: %s", e.Error())
+	}
+
+	stopped, e := repo.GetExecution(execution.Id)
+	if e != nil {
+		t.Fatalf("GetExecution returned error: %s", e.Error())
+	}
+	if stopped.Status != ExecutionStopping {
+		t.Fatalf("expected execution to be Stopping, got %s", stopped.Status)
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/G-Research/armada/pkg/api"
+)
+
+// JobHistoryEntry is an immutable snapshot of a job as it existed at a
+// particular version, recorded whenever the job is modified or cancelled.
+type JobHistoryEntry struct {
+	JobId      string
+	Version    int64
+	Job        *api.Job
+	Event      string
+	RecordedAt time.Time
+}
+
+// JobHistoryRetentionPolicy bounds how much history is kept per job. A
+// MaxVersions of 0 means unlimited versions, and a TTL of 0 means versions
+// never expire on their own.
+type JobHistoryRetentionPolicy struct {
+	MaxVersions int
+	TTL         time.Duration
+}
+
+// JobHistoryRepository keeps prior versions of jobs, indexed by the
+// compound key (JobId, Version), so that what actually ran can be audited
+// or resubmitted verbatim after the live job record has moved on.
+type JobHistoryRepository interface {
+	RecordVersion(job *api.Job, event string) (*JobHistoryEntry, error)
+	GetJobVersions(queue string, jobId string) ([]*JobHistoryEntry, error)
+	GetJobAtVersion(jobId string, version int64) (*JobHistoryEntry, error)
+}
+
+func jobHistoryKey(jobId string) string {
+	return fmt.Sprintf("Job:History:%s", jobId)
+}
+
+type RedisJobHistoryRepository struct {
+	db     *redis.Client
+	policy JobHistoryRetentionPolicy
+}
+
+func NewRedisJobHistoryRepository(db *redis.Client, policy JobHistoryRetentionPolicy) *RedisJobHistoryRepository {
+	return &RedisJobHistoryRepository{db: db, policy: policy}
+}
+
+// RecordVersion stores job as the next version for its JobId and applies
+// the configured retention policy, trimming the oldest versions first.
+func (repo *RedisJobHistoryRepository) RecordVersion(job *api.Job, event string) (*JobHistoryEntry, error) {
+	key := jobHistoryKey(job.Id)
+
+	version, e := repo.db.Incr(key + ":NextVersion").Result()
+	if e != nil {
+		return nil, e
+	}
+
+	entry := &JobHistoryEntry{
+		JobId:      job.Id,
+		Version:    version,
+		Job:        job,
+		Event:      event,
+		RecordedAt: time.Now(),
+	}
+
+	data, e := jsonMarshalJobHistoryEntry(entry)
+	if e != nil {
+		return nil, e
+	}
+
+	if e := repo.db.ZAdd(key, redis.Z{Score: float64(version), Member: data}).Err(); e != nil {
+		return nil, e
+	}
+
+	if repo.policy.MaxVersions > 0 {
+		if e := repo.db.ZRemRangeByRank(key, 0, -int64(repo.policy.MaxVersions)-1).Err(); e != nil {
+			return nil, e
+		}
+	}
+	if repo.policy.TTL > 0 {
+		if e := repo.db.Expire(key, repo.policy.TTL).Err(); e != nil {
+			return nil, e
+		}
+	}
+
+	return entry, nil
+}
+
+func (repo *RedisJobHistoryRepository) GetJobVersions(queue string, jobId string) ([]*JobHistoryEntry, error) {
+	values, e := repo.db.ZRange(jobHistoryKey(jobId), 0, -1).Result()
+	if e != nil {
+		return nil, e
+	}
+
+	entries := make([]*JobHistoryEntry, 0, len(values))
+	for _, value := range values {
+		entry, e := jsonUnmarshalJobHistoryEntry(value)
+		if e != nil {
+			return nil, e
+		}
+		if queue == "" || entry.Job.Queue == queue {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (repo *RedisJobHistoryRepository) GetJobAtVersion(jobId string, version int64) (*JobHistoryEntry, error) {
+	values, e := repo.db.ZRangeByScore(jobHistoryKey(jobId), redis.ZRangeBy{
+		Min: fmt.Sprintf("%d", version),
+		Max: fmt.Sprintf("%d", version),
+	}).Result()
+	if e != nil {
+		return nil, e
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no version %d recorded for job %s", version, jobId)
+	}
+	return jsonUnmarshalJobHistoryEntry(values[0])
+}
+
+// jobHistoryEntryWireFormat is the on-the-wire representation of a
+// JobHistoryEntry: the job itself is kept as serialized protobuf, matching
+// how job records are stored elsewhere in redis, while the surrounding
+// metadata is plain JSON for ease of inspection.
+type jobHistoryEntryWireFormat struct {
+	JobId      string
+	Version    int64
+	Event      string
+	RecordedAt time.Time
+	JobData    []byte
+}
+
+func jsonMarshalJobHistoryEntry(entry *JobHistoryEntry) (string, error) {
+	jobData, e := proto.Marshal(entry.Job)
+	if e != nil {
+		return "", e
+	}
+
+	data, e := json.Marshal(jobHistoryEntryWireFormat{
+		JobId:      entry.JobId,
+		Version:    entry.Version,
+		Event:      entry.Event,
+		RecordedAt: entry.RecordedAt,
+		JobData:    jobData,
+	})
+	if e != nil {
+		return "", e
+	}
+	return string(data), nil
+}
+
+func jsonUnmarshalJobHistoryEntry(data string) (*JobHistoryEntry, error) {
+	wire := jobHistoryEntryWireFormat{}
+	if e := json.Unmarshal([]byte(data), &wire); e != nil {
+		return nil, e
+	}
+
+	job := &api.Job{}
+	if e := proto.Unmarshal(wire.JobData, job); e != nil {
+		return nil, e
+	}
+
+	return &JobHistoryEntry{
+		JobId:      wire.JobId,
+		Version:    wire.Version,
+		Job:        job,
+		Event:      wire.Event,
+		RecordedAt: wire.RecordedAt,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G-Research/armada/pkg/api"
+)
+
+func TestJobHistoryEntryWireFormat_RoundTrip(t *testing.T) {
+	entry := &JobHistoryEntry{
+		JobId:      "job-1",
+		Version:    3,
+		Job:        &api.Job{Id: "job-1", Queue: "test-queue"},
+		Event:      "Submitted",
+		RecordedAt: time.Now().Round(time.Second),
+	}
+
+	data, e := jsonMarshalJobHistoryEntry(entry)
+	if e != nil {
+		t.Fatalf("jsonMarshalJobHistoryEntry returned error: %s", e.Error())
+	}
+
+	result, e := jsonUnmarshalJobHistoryEntry(data)
+	if e != nil {
+		t.Fatalf("jsonUnmarshalJobHistoryEntry returned error: %s", e.Error())
+	}
+
+	if result.JobId != entry.JobId || result.Version != entry.Version || result.Event != entry.Event {
+		t.Fatalf("round-tripped entry %+v does not match original %+v", result, entry)
+	}
+	if result.Job.Id != entry.Job.Id || result.Job.Queue != entry.Job.Queue {
+		t.Fatalf("round-tripped job %+v does not match original %+v", result.Job, entry.Job)
+	}
+	if !result.RecordedAt.Equal(entry.RecordedAt) {
+		t.Fatalf("round-tripped RecordedAt %s does not match original %s", result.RecordedAt, entry.RecordedAt)
+	}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/G-Research/armada/pkg/api"
+)
+
+const scheduledJobsSet = "scheduled-jobs"
+const scheduledJobsData = "scheduled-jobs:data"
+
+// ScheduledJob pairs a job with the wall-clock time at which it becomes
+// eligible to run.
+type ScheduledJob struct {
+	Job   *api.Job
+	RunAt time.Time
+}
+
+// ScheduledJobRepository stores jobs that have been submitted with a future
+// RunAt time and makes them available for activation once they become due.
+//
+// The full job is persisted (not just its id): the job hasn't been through
+// AddJobs yet, so it isn't visible to JobRepository.GetExistingJobsByIds,
+// and a scheduled job must still be able to be looked up and cancelled
+// before it ever reaches the active queue.
+type ScheduledJobRepository interface {
+	AddScheduledJobs(jobs []ScheduledJob) error
+	GetDueJobs(now time.Time) ([]*api.Job, error)
+	GetScheduledJob(jobId string) (*api.Job, error)
+	RemoveScheduledJobs(jobIds []string) error
+}
+
+type RedisScheduledJobRepository struct {
+	db *redis.Client
+}
+
+func NewRedisScheduledJobRepository(db *redis.Client) *RedisScheduledJobRepository {
+	return &RedisScheduledJobRepository{db: db}
+}
+
+func (repo *RedisScheduledJobRepository) AddScheduledJobs(jobs []ScheduledJob) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	members := make([]redis.Z, 0, len(jobs))
+	data := make(map[string]interface{}, len(jobs))
+	for _, scheduledJob := range jobs {
+		jobData, e := proto.Marshal(scheduledJob.Job)
+		if e != nil {
+			return e
+		}
+		members = append(members, redis.Z{
+			Score:  float64(scheduledJob.RunAt.Unix()),
+			Member: scheduledJob.Job.Id,
+		})
+		data[scheduledJob.Job.Id] = jobData
+	}
+
+	if e := repo.db.HMSet(scheduledJobsData, data).Err(); e != nil {
+		return e
+	}
+	return repo.db.ZAdd(scheduledJobsSet, members...).Err()
+}
+
+func (repo *RedisScheduledJobRepository) GetDueJobs(now time.Time) ([]*api.Job, error) {
+	jobIds, e := repo.db.ZRangeByScore(scheduledJobsSet, redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("%d", now.Unix()),
+	}).Result()
+	if e != nil {
+		return nil, e
+	}
+	return repo.getJobs(jobIds)
+}
+
+func (repo *RedisScheduledJobRepository) GetScheduledJob(jobId string) (*api.Job, error) {
+	jobs, e := repo.getJobs([]string{jobId})
+	if e != nil {
+		return nil, e
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("no scheduled job found with id %s", jobId)
+	}
+	return jobs[0], nil
+}
+
+func (repo *RedisScheduledJobRepository) getJobs(jobIds []string) ([]*api.Job, error) {
+	if len(jobIds) == 0 {
+		return nil, nil
+	}
+
+	values, e := repo.db.HMGet(scheduledJobsData, jobIds...).Result()
+	if e != nil {
+		return nil, e
+	}
+
+	jobs := make([]*api.Job, 0, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		job := &api.Job{}
+		if e := proto.Unmarshal([]byte(data), job); e != nil {
+			return nil, fmt.Errorf("could not unmarshal scheduled job %s: %s", jobIds[i], e.Error())
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (repo *RedisScheduledJobRepository) RemoveScheduledJobs(jobIds []string) error {
+	if len(jobIds) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(jobIds))
+	for i, id := range jobIds {
+		members[i] = id
+	}
+
+	if e := repo.db.ZRem(scheduledJobsSet, members...).Err(); e != nil {
+		return e
+	}
+	return repo.db.HDel(scheduledJobsData, jobIds...).Err()
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/G-Research/armada/internal/armada/authorization"
+	"github.com/G-Research/armada/internal/armada/authorization/permissions"
+	"github.com/G-Research/armada/internal/armada/repository"
+	"github.com/G-Research/armada/pkg/api"
+)
+
+const jobSetCancelExecutionKind = "CancelJobSet"
+
+// NOTE: api.Execution, api.ExecutionStartRequest, api.ExecutionRequest,
+// api.ExecutionList, api.ExecutionListRequest and api.TaskLog/TaskLogRequest
+// are new wire types this file depends on; they need a companion .proto
+// change (plus regenerated *.pb.go and AdminServer service registration)
+// before this RPC set actually compiles and is reachable. Land that change
+// alongside this one.
+
+// AdminServer exposes long-running maintenance operations (bulk cancel,
+// queue GC, and future admin jobs) as Executions that can be started
+// asynchronously and polled for progress, rather than blocking an RPC for
+// as long as the operation takes to run.
+type AdminServer struct {
+	submitServer        *SubmitServer
+	executionRepository repository.ExecutionRepository
+}
+
+func NewAdminServer(
+	submitServer *SubmitServer,
+	executionRepository repository.ExecutionRepository) *AdminServer {
+
+	return &AdminServer{
+		submitServer:        submitServer,
+		executionRepository: executionRepository,
+	}
+}
+
+// CancelJobSet starts an Execution that cancels every active job in a job
+// set, one Task per job, running in the background so that cancelling tens
+// of thousands of jobs doesn't hold the RPC open.
+func (server *AdminServer) CancelJobSet(ctx context.Context, req *api.JobSetCancelRequest) (*api.Execution, error) {
+	if e := server.submitServer.checkQueuePermission(ctx, req.Queue, permissions.CancelJobs, permissions.CancelAnyJobs); e != nil {
+		return nil, e
+	}
+
+	principal := authorization.GetPrincipal(ctx)
+
+	ids, e := server.submitServer.jobRepository.GetActiveJobIds(req.Queue, req.JobSetId)
+	if e != nil {
+		return nil, status.Errorf(codes.Aborted, e.Error())
+	}
+	jobs, e := server.submitServer.jobRepository.GetExistingJobsByIds(ids)
+	if e != nil {
+		return nil, status.Errorf(codes.Internal, e.Error())
+	}
+
+	execution, e := server.executionRepository.CreateExecution(jobSetCancelExecutionKind, principal.GetName())
+	if e != nil {
+		return nil, status.Errorf(codes.Internal, e.Error())
+	}
+
+	// The background run outlives this RPC's ctx, but the permission checks
+	// and admission webhooks it drives through cancelJobs still need to see
+	// who kicked the execution off, so carry the principal across explicitly
+	// rather than handing cancelJobs a bare, anonymous context.Background().
+	backgroundCtx := authorization.WithPrincipal(context.Background(), principal)
+	go server.runCancelJobSet(backgroundCtx, execution.Id, req.Queue, jobs)
+
+	return executionProtoFromRepository(execution), nil
+}
+
+func (server *AdminServer) runCancelJobSet(ctx context.Context, executionId string, queue string, jobs []*api.Job) {
+	for _, job := range jobs {
+		taskId := fmt.Sprintf("%s-%s", executionId, job.Id)
+		if e := server.executionRepository.AddTask(executionId, taskId); e != nil {
+			log.Errorf("Error adding task %s to execution %s: %s", taskId, executionId, e.Error())
+			continue
+		}
+
+		execution, e := server.executionRepository.GetExecution(executionId)
+		if e == nil && execution.Status == repository.ExecutionStopping {
+			server.executionRepository.AppendTaskLog(taskId, "skipped: execution stopped")
+			server.executionRepository.UpdateTaskStatus(taskId, repository.TaskSkipped, nil)
+			break
+		}
+
+		server.executionRepository.UpdateTaskStatus(taskId, repository.TaskRunning, nil)
+
+		_, e = server.submitServer.cancelJobs(ctx, queue, []*api.Job{job})
+		if e != nil {
+			server.executionRepository.AppendTaskLog(taskId, e.Error())
+			server.executionRepository.UpdateTaskStatus(taskId, repository.TaskFailed, e)
+			continue
+		}
+
+		server.executionRepository.AppendTaskLog(taskId, fmt.Sprintf("cancelled job %s", job.Id))
+		server.executionRepository.UpdateTaskStatus(taskId, repository.TaskSucceeded, nil)
+	}
+
+	if e := server.executionRepository.CompleteExecution(executionId); e != nil {
+		log.Errorf("Error completing execution %s: %s", executionId, e.Error())
+	}
+}
+
+func (server *AdminServer) StartExecution(ctx context.Context, req *api.ExecutionStartRequest) (*api.Execution, error) {
+	switch req.Kind {
+	case jobSetCancelExecutionKind:
+		return server.CancelJobSet(ctx, &api.JobSetCancelRequest{Queue: req.Queue, JobSetId: req.JobSetId})
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown execution kind %q", req.Kind)
+	}
+}
+
+func (server *AdminServer) GetExecution(ctx context.Context, req *api.ExecutionRequest) (*api.Execution, error) {
+	execution, e := server.executionRepository.GetExecution(req.Id)
+	if e != nil {
+		return nil, status.Errorf(codes.NotFound, e.Error())
+	}
+	return executionProtoFromRepository(execution), nil
+}
+
+func (server *AdminServer) ListExecutions(ctx context.Context, req *api.ExecutionListRequest) (*api.ExecutionList, error) {
+	executions, e := server.executionRepository.ListExecutions(req.Kind, repository.ExecutionStatus(req.Status))
+	if e != nil {
+		return nil, status.Errorf(codes.Internal, e.Error())
+	}
+
+	result := &api.ExecutionList{Executions: make([]*api.Execution, 0, len(executions))}
+	for _, execution := range executions {
+		result.Executions = append(result.Executions, executionProtoFromRepository(execution))
+	}
+	return result, nil
+}
+
+func (server *AdminServer) StopExecution(ctx context.Context, req *api.ExecutionRequest) (*types.Empty, error) {
+	if e := server.executionRepository.StopExecution(req.Id); e != nil {
+		return nil, status.Errorf(codes.NotFound, e.Error())
+	}
+	return &types.Empty{}, nil
+}
+
+func (server *AdminServer) GetTaskLog(ctx context.Context, req *api.TaskLogRequest) (*api.TaskLog, error) {
+	lines, e := server.executionRepository.GetTaskLog(req.TaskId)
+	if e != nil {
+		return nil, status.Errorf(codes.Internal, e.Error())
+	}
+	return &api.TaskLog{TaskId: req.TaskId, Lines: lines}, nil
+}
+
+func executionProtoFromRepository(execution *repository.Execution) *api.Execution {
+	return &api.Execution{
+		Id:       execution.Id,
+		Kind:     execution.Kind,
+		Operator: execution.Operator,
+		Status:   string(execution.Status),
+		TaskIds:  execution.TaskIds,
+	}
+}
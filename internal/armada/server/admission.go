@@ -0,0 +1,171 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/armada/internal/armada/authorization"
+	"github.com/G-Research/armada/pkg/api"
+)
+
+// AdmissionRejection is a structured reason a webhook gave for rejecting a
+// job, surfaced back to the caller in JobSubmitResponseItem.Error rather
+// than as an opaque transport error.
+type AdmissionRejection struct {
+	JobIndex int
+	Reason   string
+}
+
+func (rejection *AdmissionRejection) Error() string {
+	return rejection.Reason
+}
+
+// AdmissionController is invoked by SubmitServer after jobs are created but
+// before they are queued, and before jobs are cancelled. It may mutate jobs
+// in place (inject labels, resource floors, priority classes) or reject
+// individual jobs by returning an AdmissionRejection whose JobIndex points
+// into the returned job slice, so that
+// org-wide policy (image allow-lists, namespace quotas) can be enforced
+// centrally without forking the server.
+type AdmissionController interface {
+	AdmitJobs(ctx context.Context, principal authorization.Principal, jobs []*api.Job) ([]*api.Job, []*AdmissionRejection, error)
+	AdmitCancellation(ctx context.Context, principal authorization.Principal, jobs []*api.Job) error
+}
+
+// applyAdmissionControllers runs jobs through each controller in turn,
+// letting each mutate the slice before the next sees it, and collects any
+// rejections keyed by job so the caller can report them per-item.
+func applyAdmissionControllers(ctx context.Context, controllers []AdmissionController, principal authorization.Principal, jobs []*api.Job) ([]*api.Job, map[string]string, error) {
+	rejected := map[string]string{}
+	admitted := jobs
+
+	for _, controller := range controllers {
+		var rejections []*AdmissionRejection
+		var e error
+		admitted, rejections, e = controller.AdmitJobs(ctx, principal, admitted)
+		if e != nil {
+			return nil, nil, e
+		}
+		for _, rejection := range rejections {
+			if rejection.JobIndex < 0 || rejection.JobIndex >= len(admitted) {
+				continue
+			}
+			rejected[admitted[rejection.JobIndex].Id] = rejection.Reason
+		}
+	}
+
+	remaining := make([]*api.Job, 0, len(admitted))
+	for _, job := range admitted {
+		if _, isRejected := rejected[job.Id]; !isRejected {
+			remaining = append(remaining, job)
+		}
+	}
+
+	return remaining, rejected, nil
+}
+
+func admitCancellation(ctx context.Context, controllers []AdmissionController, principal authorization.Principal, jobs []*api.Job) error {
+	for _, controller := range controllers {
+		if e := controller.AdmitCancellation(ctx, principal, jobs); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// WebhookAdmissionController dispatches admission decisions to an external
+// HTTPS endpoint. If the endpoint is unreachable or times out, the
+// configured FailOpen policy decides whether jobs are admitted anyway or
+// the submission is rejected outright.
+type WebhookAdmissionController struct {
+	url      string
+	client   *http.Client
+	failOpen bool
+}
+
+func NewWebhookAdmissionController(url string, timeout time.Duration, failOpen bool) *WebhookAdmissionController {
+	return &WebhookAdmissionController{
+		url:      url,
+		client:   &http.Client{Timeout: timeout},
+		failOpen: failOpen,
+	}
+}
+
+type admissionWebhookRequest struct {
+	Principal string     `json:"principal"`
+	Jobs      []*api.Job `json:"jobs"`
+}
+
+type admissionWebhookResponse struct {
+	Jobs       []*api.Job            `json:"jobs"`
+	Rejections []*AdmissionRejection `json:"rejections"`
+}
+
+func (controller *WebhookAdmissionController) AdmitJobs(ctx context.Context, principal authorization.Principal, jobs []*api.Job) ([]*api.Job, []*AdmissionRejection, error) {
+	body, e := json.Marshal(admissionWebhookRequest{Principal: principal.GetName(), Jobs: jobs})
+	if e != nil {
+		return nil, nil, e
+	}
+
+	request, e := http.NewRequest(http.MethodPost, controller.url, bytes.NewReader(body))
+	if e != nil {
+		return nil, nil, e
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+
+	httpResponse, e := controller.client.Do(request)
+	if e != nil {
+		return controller.handleUnreachable(jobs, e)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return controller.handleUnreachable(jobs, fmt.Errorf("admission webhook returned status %d", httpResponse.StatusCode))
+	}
+
+	var response admissionWebhookResponse
+	if e := json.NewDecoder(httpResponse.Body).Decode(&response); e != nil {
+		return controller.handleUnreachable(jobs, e)
+	}
+
+	// A webhook that only wants to reject a subset of jobs shouldn't have to
+	// echo back the rest verbatim - treat an omitted/null "jobs" field as
+	// "unchanged" rather than silently admitting nothing.
+	admitted := response.Jobs
+	if admitted == nil {
+		admitted = jobs
+	}
+
+	return admitted, response.Rejections, nil
+}
+
+func (controller *WebhookAdmissionController) AdmitCancellation(ctx context.Context, principal authorization.Principal, jobs []*api.Job) error {
+	_, rejections, e := controller.AdmitJobs(ctx, principal, jobs)
+	if e != nil {
+		return e
+	}
+	if len(rejections) > 0 {
+		reasons := make([]string, 0, len(rejections))
+		for _, rejection := range rejections {
+			reasons = append(reasons, rejection.Reason)
+		}
+		return fmt.Errorf("cancellation rejected by admission webhook: %s", strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+func (controller *WebhookAdmissionController) handleUnreachable(jobs []*api.Job, e error) ([]*api.Job, []*AdmissionRejection, error) {
+	if controller.failOpen {
+		log.Warnf("Admission webhook %s unreachable, admitting jobs (fail-open): %s", controller.url, e.Error())
+		return jobs, nil, nil
+	}
+	return nil, nil, e
+}
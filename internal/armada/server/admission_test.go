@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/G-Research/armada/internal/armada/authorization"
+	"github.com/G-Research/armada/pkg/api"
+)
+
+type fakePrincipal struct{}
+
+func (fakePrincipal) GetName() string { return "test-principal" }
+
+func TestWebhookAdmissionController_AdmitJobs_UnchangedWhenJobsOmitted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(admissionWebhookResponse{})
+	}))
+	defer server.Close()
+
+	controller := NewWebhookAdmissionController(server.URL, time.Second, false)
+	jobs := []*api.Job{{Id: "job-0"}, {Id: "job-1"}}
+
+	admitted, rejections, e := controller.AdmitJobs(context.Background(), fakePrincipal{}, jobs)
+	if e != nil {
+		t.Fatalf("AdmitJobs returned error: %s", e.Error())
+	}
+	if len(rejections) != 0 {
+		t.Fatalf("expected no rejections, got %+v", rejections)
+	}
+	if len(admitted) != len(jobs) {
+		t.Fatalf("expected omitted jobs field to mean unchanged, got %+v", admitted)
+	}
+}
+
+func TestWebhookAdmissionController_AdmitJobs_RejectsSubset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(admissionWebhookResponse{
+			Rejections: []*AdmissionRejection{{JobIndex: 0, Reason: "quota exceeded"}},
+		})
+	}))
+	defer server.Close()
+
+	controller := NewWebhookAdmissionController(server.URL, time.Second, false)
+	jobs := []*api.Job{{Id: "job-0"}, {Id: "job-1"}}
+
+	admitted, rejections, e := controller.AdmitJobs(context.Background(), fakePrincipal{}, jobs)
+	if e != nil {
+		t.Fatalf("AdmitJobs returned error: %s", e.Error())
+	}
+	if len(admitted) != len(jobs) {
+		t.Fatalf("expected jobs to remain unchanged alongside rejections, got %+v", admitted)
+	}
+	if len(rejections) != 1 || rejections[0].Reason != "quota exceeded" {
+		t.Fatalf("expected the webhook's rejection to be surfaced, got %+v", rejections)
+	}
+}
+
+func TestWebhookAdmissionController_AdmitCancellation_HonorsRejections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(admissionWebhookResponse{
+			Rejections: []*AdmissionRejection{{JobIndex: 0, Reason: "job is protected"}},
+		})
+	}))
+	defer server.Close()
+
+	controller := NewWebhookAdmissionController(server.URL, time.Second, false)
+	jobs := []*api.Job{{Id: "job-0"}}
+
+	e := controller.AdmitCancellation(context.Background(), fakePrincipal{}, jobs)
+	if e == nil {
+		t.Fatalf("expected a webhook rejection to block cancellation")
+	}
+}
+
+func TestWebhookAdmissionController_AdmitCancellation_AllowsWhenUnrejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(admissionWebhookResponse{})
+	}))
+	defer server.Close()
+
+	controller := NewWebhookAdmissionController(server.URL, time.Second, false)
+	jobs := []*api.Job{{Id: "job-0"}}
+
+	if e := controller.AdmitCancellation(context.Background(), fakePrincipal{}, jobs); e != nil {
+		t.Fatalf("expected cancellation to be allowed, got error: %s", e.Error())
+	}
+}
+
+var _ authorization.Principal = fakePrincipal{}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/nats-io/nats.go"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/armada/internal/armada/repository"
+	"github.com/G-Research/armada/pkg/api"
+)
+
+// StartJobRequest is published by an external scheduler (e.g. a Slurm
+// side-car) to tell Armada about a job it did not originate so that it
+// shows up alongside Armada-submitted jobs. JobId is the external
+// scheduler's own id for the job, not an Armada-assigned job id.
+type StartJobRequest struct {
+	JobId     string            `json:"jobId"`
+	Queue     string            `json:"queue"`
+	JobSetId  string            `json:"jobSetId"`
+	Cluster   string            `json:"cluster"`
+	StartTime time.Time         `json:"startTime"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// StopJobRequest tells Armada that a job it did not originate has
+// finished, carrying the terminal state it finished in.
+type StopJobRequest struct {
+	JobId    string    `json:"jobId"`
+	Queue    string    `json:"queue"`
+	JobSetId string    `json:"jobSetId"`
+	Cluster  string    `json:"cluster"`
+	StopTime time.Time `json:"stopTime"`
+	State    string    `json:"state"`
+}
+
+// NatsIngressConsumer subscribes to a NATS subject carrying
+// StartJobRequest/StopJobRequest messages from external schedulers and
+// reports them as Running events and as Succeeded/Failed/Cancelled events
+// respectively, the same way Armada reports
+// lifecycle events for jobs it originated itself - it does not resubmit
+// or cancel anything, since these jobs were never created by Armada's
+// own SubmitJobs in the first place.
+type NatsIngressConsumer struct {
+	conn       *nats.Conn
+	subject    string
+	eventStore repository.EventStore
+}
+
+func NewNatsIngressConsumer(conn *nats.Conn, subject string, eventStore repository.EventStore) *NatsIngressConsumer {
+	return &NatsIngressConsumer{conn: conn, subject: subject, eventStore: eventStore}
+}
+
+// Run subscribes to the configured subject and processes messages until
+// ctx is cancelled.
+func (consumer *NatsIngressConsumer) Run(ctx context.Context) error {
+	messages := make(chan *nats.Msg, 64)
+	subscription, e := consumer.conn.ChanSubscribe(consumer.subject, messages)
+	if e != nil {
+		return e
+	}
+	defer subscription.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-messages:
+			if e := consumer.handleMessage(msg.Data); e != nil {
+				log.Errorf("Error handling message from subject %s: %s", consumer.subject, e.Error())
+			}
+		}
+	}
+}
+
+func (consumer *NatsIngressConsumer) handleMessage(data []byte) error {
+	var envelope struct {
+		Type string `json:"type"`
+	}
+	if e := json.Unmarshal(data, &envelope); e != nil {
+		return e
+	}
+
+	switch envelope.Type {
+	case "StartJobRequest":
+		var request StartJobRequest
+		if e := json.Unmarshal(data, &request); e != nil {
+			return e
+		}
+		return consumer.handleStartJob(request)
+	case "StopJobRequest":
+		var request StopJobRequest
+		if e := json.Unmarshal(data, &request); e != nil {
+			return e
+		}
+		return consumer.handleStopJob(request)
+	default:
+		return fmt.Errorf("unknown message type %q", envelope.Type)
+	}
+}
+
+func (consumer *NatsIngressConsumer) handleStartJob(request StartJobRequest) error {
+	created, e := types.TimestampProto(request.StartTime)
+	if e != nil {
+		return e
+	}
+
+	return consumer.eventStore.ReportEvents([]*api.EventMessage{
+		{
+			Events: &api.EventMessage_Running{
+				Running: &api.JobRunningEvent{
+					JobId:     request.JobId,
+					Queue:     request.Queue,
+					JobSetId:  request.JobSetId,
+					ClusterId: request.Cluster,
+					Created:   created,
+				},
+			},
+		},
+	})
+}
+
+func (consumer *NatsIngressConsumer) handleStopJob(request StopJobRequest) error {
+	created, e := types.TimestampProto(request.StopTime)
+	if e != nil {
+		return e
+	}
+
+	event, e := terminalEventFor(request, created)
+	if e != nil {
+		return e
+	}
+
+	return consumer.eventStore.ReportEvents([]*api.EventMessage{event})
+}
+
+// terminalEventFor maps an external scheduler's reported terminal state onto
+// the matching api.EventMessage oneof variant, since EventMessage has no
+// generic JobId/State fields of its own - each lifecycle event is its own
+// concrete type wrapped in the Events oneof.
+func terminalEventFor(request StopJobRequest, created *types.Timestamp) (*api.EventMessage, error) {
+	switch request.State {
+	case "Succeeded":
+		return &api.EventMessage{
+			Events: &api.EventMessage_Succeeded{
+				Succeeded: &api.JobSucceededEvent{
+					JobId:     request.JobId,
+					Queue:     request.Queue,
+					JobSetId:  request.JobSetId,
+					ClusterId: request.Cluster,
+					Created:   created,
+				},
+			},
+		}, nil
+	case "Failed":
+		return &api.EventMessage{
+			Events: &api.EventMessage_Failed{
+				Failed: &api.JobFailedEvent{
+					JobId:     request.JobId,
+					Queue:     request.Queue,
+					JobSetId:  request.JobSetId,
+					ClusterId: request.Cluster,
+					Created:   created,
+				},
+			},
+		}, nil
+	case "Cancelled":
+		return &api.EventMessage{
+			Events: &api.EventMessage_Cancelled{
+				Cancelled: &api.JobCancelledEvent{
+					JobId:     request.JobId,
+					Queue:     request.Queue,
+					JobSetId:  request.JobSetId,
+					ClusterId: request.Cluster,
+					Created:   created,
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown terminal job state %q", request.State)
+	}
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	log "github.com/sirupsen/logrus"
@@ -16,12 +17,29 @@ import (
 	"github.com/G-Research/armada/pkg/api"
 )
 
+// scheduledJobPollInterval is how often the server checks the scheduled
+// job set for jobs that have become due.
+const scheduledJobPollInterval = 5 * time.Second
+
+// NOTE: this file depends on several wire types and permission constants
+// that don't exist yet - the RunAt field on api.Job/api.JobSubmitRequestItem,
+// api.JobValidationResponse/api.JobValidationItem, api.CheckAccessRequest/
+// api.CheckAccessResponse, api.JobHistoryRequest/api.JobHistoryResponse/
+// api.JobHistoryItem/api.JobAtVersionRequest, and
+// permissions.ReadJobHistory/permissions.ReadAnyJobHistory. These need a
+// companion .proto and permissions change (with regenerated *.pb.go and
+// service registration) before ValidateJobs, CheckAccess, GetJobVersions,
+// GetJobAtVersion and ReSubmitJob actually compile and are reachable.
+
 type SubmitServer struct {
 	permissions              authorization.PermissionChecker
 	jobRepository            repository.JobRepository
 	queueRepository          repository.QueueRepository
 	eventStore               repository.EventStore
 	schedulingInfoRepository repository.SchedulingInfoRepository
+	scheduledJobRepository   repository.ScheduledJobRepository
+	jobHistoryRepository     repository.JobHistoryRepository
+	admissionControllers     []AdmissionController
 }
 
 func NewSubmitServer(
@@ -29,14 +47,83 @@ func NewSubmitServer(
 	jobRepository repository.JobRepository,
 	queueRepository repository.QueueRepository,
 	eventStore repository.EventStore,
-	schedulingInfoRepository repository.SchedulingInfoRepository) *SubmitServer {
+	schedulingInfoRepository repository.SchedulingInfoRepository,
+	scheduledJobRepository repository.ScheduledJobRepository,
+	jobHistoryRepository repository.JobHistoryRepository,
+	admissionControllers []AdmissionController) *SubmitServer {
 
 	return &SubmitServer{
 		permissions:              permissions,
 		jobRepository:            jobRepository,
 		queueRepository:          queueRepository,
 		eventStore:               eventStore,
-		schedulingInfoRepository: schedulingInfoRepository}
+		schedulingInfoRepository: schedulingInfoRepository,
+		scheduledJobRepository:   scheduledJobRepository,
+		jobHistoryRepository:     jobHistoryRepository,
+		admissionControllers:     admissionControllers}
+}
+
+// RunScheduledJobActivator polls the scheduled job set and moves due jobs
+// onto the normal queue, emitting the same Submitted/Queued events as an
+// immediate SubmitJobs call would. It runs until ctx is cancelled and is
+// intended to be started once per server in its own goroutine.
+func (server *SubmitServer) RunScheduledJobActivator(ctx context.Context) {
+	ticker := time.NewTicker(scheduledJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if e := server.activateDueJobs(); e != nil {
+				log.Errorf("Error activating scheduled jobs: %s", e.Error())
+			}
+		}
+	}
+}
+
+func (server *SubmitServer) activateDueJobs() error {
+	jobs, e := server.scheduledJobRepository.GetDueJobs(time.Now())
+	if e != nil {
+		return e
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	jobIds := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		jobIds = append(jobIds, job.Id)
+	}
+
+	// Remove before adding: a job must leave the scheduled set before it's
+	// queued, so a crash between the two steps loses the job rather than
+	// re-activating (and double-enqueuing) it on the next tick.
+	if e := server.scheduledJobRepository.RemoveScheduledJobs(jobIds); e != nil {
+		return e
+	}
+
+	e = reportSubmitted(server.eventStore, jobs)
+	if e != nil {
+		return e
+	}
+
+	submissionResults, e := server.jobRepository.AddJobs(jobs)
+	if e != nil {
+		return e
+	}
+
+	activated := make([]*api.Job, 0, len(submissionResults))
+	for _, result := range submissionResults {
+		if result.Error != nil {
+			log.Errorf("Error activating scheduled job id %s: %s", result.Job.Id, result.Error.Error())
+			continue
+		}
+		activated = append(activated, result.Job)
+	}
+
+	return reportQueued(server.eventStore, activated)
 }
 
 func (server *SubmitServer) GetQueueInfo(ctx context.Context, req *api.QueueInfoRequest) (*api.QueueInfo, error) {
@@ -85,24 +172,53 @@ func (server *SubmitServer) SubmitJobs(ctx context.Context, req *api.JobSubmitRe
 	if e != nil {
 		return nil, status.Errorf(codes.InvalidArgument, e.Error())
 	}
+	attachRunAt(req, jobs)
 
-	e = server.validateJobsCanBeScheduled(jobs)
+	// Admission controllers can mutate jobs (inject labels, resource floors,
+	// priority classes), and those mutations can change whether a job is
+	// schedulable - so validate the admitted set, not the raw one.
+	admittedJobs, rejections, e := applyAdmissionControllers(ctx, server.admissionControllers, principal, jobs)
+	if e != nil {
+		return nil, status.Errorf(codes.PermissionDenied, e.Error())
+	}
+
+	e = server.validateJobsCanBeScheduled(admittedJobs)
 	if e != nil {
 		return nil, status.Errorf(codes.InvalidArgument, e.Error())
 	}
 
-	e = reportSubmitted(server.eventStore, jobs)
+	// Recording a version is an audit side-channel, not the submission
+	// itself - jobs rejected by an admission controller get no history
+	// entry, and a history write failure is only logged rather than failing
+	// a submission that otherwise succeeded. Entries are written before
+	// AddJobs, so a job that AddJobs then fails to store still has one.
+	for _, job := range admittedJobs {
+		if _, e := server.jobHistoryRepository.RecordVersion(job, "Submitted"); e != nil {
+			log.Errorf("Error recording history for submitted job id %s: %s", job.Id, e.Error())
+		}
+	}
+
+	immediateJobs, scheduledJobs := splitScheduledJobs(admittedJobs)
+
+	if len(scheduledJobs) > 0 {
+		e = server.scheduledJobRepository.AddScheduledJobs(scheduledJobs)
+		if e != nil {
+			return nil, status.Errorf(codes.Aborted, e.Error())
+		}
+	}
+
+	e = reportSubmitted(server.eventStore, immediateJobs)
 	if e != nil {
 		return nil, status.Errorf(codes.Aborted, e.Error())
 	}
 
-	submissionResults, e := server.jobRepository.AddJobs(jobs)
+	submissionResults, e := server.jobRepository.AddJobs(immediateJobs)
 	if e != nil {
 		return nil, status.Errorf(codes.Aborted, e.Error())
 	}
 
 	result := &api.JobSubmitResponse{
-		JobResponseItems: make([]*api.JobSubmitResponseItem, 0, len(submissionResults)),
+		JobResponseItems: make([]*api.JobSubmitResponseItem, 0, len(submissionResults)+len(scheduledJobs)+len(rejections)),
 	}
 
 	for _, submissionResult := range submissionResults {
@@ -112,8 +228,14 @@ func (server *SubmitServer) SubmitJobs(ctx context.Context, req *api.JobSubmitRe
 		}
 		result.JobResponseItems = append(result.JobResponseItems, jobResponse)
 	}
+	for _, scheduledJob := range scheduledJobs {
+		result.JobResponseItems = append(result.JobResponseItems, &api.JobSubmitResponseItem{JobId: scheduledJob.Job.Id})
+	}
+	for jobId, reason := range rejections {
+		result.JobResponseItems = append(result.JobResponseItems, &api.JobSubmitResponseItem{JobId: jobId, Error: reason})
+	}
 
-	e = reportQueued(server.eventStore, jobs)
+	e = reportQueued(server.eventStore, immediateJobs)
 	if e != nil {
 		return result, status.Errorf(codes.Aborted, e.Error())
 	}
@@ -121,6 +243,113 @@ func (server *SubmitServer) SubmitJobs(ctx context.Context, req *api.JobSubmitRe
 	return result, nil
 }
 
+// ValidateJobs runs the same checks SubmitJobs would - queue permission,
+// job creation, and schedulability - without enqueuing anything, so UIs
+// and CI systems can pre-flight a submission and see per-item diagnostics.
+func (server *SubmitServer) ValidateJobs(ctx context.Context, req *api.JobSubmitRequest) (*api.JobValidationResponse, error) {
+	if e := server.checkQueuePermission(ctx, req.Queue, permissions.SubmitJobs, permissions.SubmitAnyJobs); e != nil {
+		return nil, e
+	}
+
+	principal := authorization.GetPrincipal(ctx)
+
+	jobs, e := server.jobRepository.CreateJobs(req, principal)
+	if e != nil {
+		return nil, status.Errorf(codes.InvalidArgument, e.Error())
+	}
+
+	allClusterSchedulingInfo, e := server.schedulingInfoRepository.GetClusterSchedulingInfo()
+	if e != nil {
+		return nil, status.Errorf(codes.Internal, e.Error())
+	}
+	activeClusterSchedulingInfo := scheduling.FilterActiveClusterSchedulingInfoReports(allClusterSchedulingInfo)
+
+	response := &api.JobValidationResponse{
+		JobDiagnostics: make([]*api.JobValidationItem, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		diagnosis := schedulingDiagnosisFor(job, activeClusterSchedulingInfo)
+		response.JobDiagnostics = append(response.JobDiagnostics, &api.JobValidationItem{
+			JobId:            job.Id,
+			Schedulable:      diagnosis.Schedulable,
+			MatchingClusters: diagnosis.MatchingClusters,
+			Reasons:          diagnosis.Reasons,
+		})
+	}
+
+	return response, nil
+}
+
+// anyQueuePermissions maps each owner-scoped permission to the broader
+// permission that substitutes for it when the caller doesn't own the queue,
+// mirroring the basic-vs-any-queue pairs checkQueuePermission enforces.
+var anyQueuePermissions = map[permissions.Permission]permissions.Permission{
+	permissions.SubmitJobs:     permissions.SubmitAnyJobs,
+	permissions.CancelJobs:     permissions.CancelAnyJobs,
+	permissions.ReadJobHistory: permissions.ReadAnyJobHistory,
+}
+
+// CheckAccess reports whether the calling principal holds each of the
+// given permissions on queue, letting a UI or CI system probe ACLs up
+// front instead of discovering them via a failed SubmitJobs call.
+func (server *SubmitServer) CheckAccess(ctx context.Context, req *api.CheckAccessRequest) (*api.CheckAccessResponse, error) {
+	queue, e := server.queueRepository.GetQueue(req.Queue)
+	if e != nil {
+		return nil, status.Errorf(codes.NotFound, "Could not load queue: %s", e.Error())
+	}
+	owns := server.permissions.UserOwns(ctx, queue)
+
+	response := &api.CheckAccessResponse{Granted: make(map[string]bool, len(req.Permissions))}
+	for _, permissionName := range req.Permissions {
+		permission := permissions.Permission(permissionName)
+		if !owns {
+			if anyQueuePermission, ok := anyQueuePermissions[permission]; ok {
+				permission = anyQueuePermission
+			}
+		}
+		response.Granted[permissionName] = checkPermission(server.permissions, ctx, permission) == nil
+	}
+
+	return response, nil
+}
+
+// attachRunAt copies each request item's RunAt onto the corresponding
+// created job, so that once jobs have passed the admission controllers
+// (which may drop, mutate, or reorder them) RunAt travels on the job
+// itself instead of needing to be re-derived from its original request
+// index.
+func attachRunAt(req *api.JobSubmitRequest, jobs []*api.Job) {
+	for i, job := range jobs {
+		if i < len(req.JobRequestItems) {
+			job.RunAt = req.JobRequestItems[i].RunAt
+		}
+	}
+}
+
+// splitScheduledJobs partitions jobs into those that should be queued
+// immediately and those with a future RunAt that belong on the scheduled
+// job set instead, pairing each deferred job with its activation time.
+func splitScheduledJobs(jobs []*api.Job) ([]*api.Job, []repository.ScheduledJob) {
+	immediateJobs := make([]*api.Job, 0, len(jobs))
+	scheduledJobs := make([]repository.ScheduledJob, 0)
+
+	now := time.Now()
+	for _, job := range jobs {
+		var runAt time.Time
+		if job.RunAt != nil {
+			runAt, _ = types.TimestampFromProto(job.RunAt)
+		}
+
+		if runAt.After(now) {
+			scheduledJobs = append(scheduledJobs, repository.ScheduledJob{Job: job, RunAt: runAt})
+		} else {
+			immediateJobs = append(immediateJobs, job)
+		}
+	}
+
+	return immediateJobs, scheduledJobs
+}
+
 func (server *SubmitServer) validateJobsCanBeScheduled(jobs []*api.Job) error {
 	allClusterSchedulingInfo, e := server.schedulingInfoRepository.GetClusterSchedulingInfo()
 	if e != nil {
@@ -129,7 +358,7 @@ func (server *SubmitServer) validateJobsCanBeScheduled(jobs []*api.Job) error {
 
 	activeClusterSchedulingInfo := scheduling.FilterActiveClusterSchedulingInfoReports(allClusterSchedulingInfo)
 	for i, job := range jobs {
-		if !validateJobCanBeScheduled(job, activeClusterSchedulingInfo) {
+		if !schedulingDiagnosisFor(job, activeClusterSchedulingInfo).Schedulable {
 			return fmt.Errorf("job with index %d is not schedulable on any cluster", i)
 		}
 	}
@@ -138,12 +367,37 @@ func (server *SubmitServer) validateJobsCanBeScheduled(jobs []*api.Job) error {
 }
 
 func validateJobCanBeScheduled(job *api.Job, allClusterSchedulingInfos map[string]*api.ClusterSchedulingInfoReport) bool {
-	for _, schedulingInfo := range allClusterSchedulingInfos {
+	return schedulingDiagnosisFor(job, allClusterSchedulingInfos).Schedulable
+}
+
+// jobSchedulingDiagnosis is the structured result of checking a single job
+// against every active cluster, so callers like ValidateJobs can explain
+// why a job isn't schedulable rather than just reporting a bool.
+type jobSchedulingDiagnosis struct {
+	Schedulable      bool
+	MatchingClusters []string
+	Reasons          map[string]string
+}
+
+// schedulingDiagnosisFor matches job against every active cluster,
+// factoring out the scheduling-match logic from validateJobCanBeScheduled
+// so it can be reused to build per-cluster diagnostics rather than a bool.
+func schedulingDiagnosisFor(job *api.Job, allClusterSchedulingInfos map[string]*api.ClusterSchedulingInfoReport) jobSchedulingDiagnosis {
+	diagnosis := jobSchedulingDiagnosis{
+		MatchingClusters: []string{},
+		Reasons:          map[string]string{},
+	}
+
+	for cluster, schedulingInfo := range allClusterSchedulingInfos {
 		if scheduling.MatchSchedulingRequirements(job, schedulingInfo) {
-			return true
+			diagnosis.Schedulable = true
+			diagnosis.MatchingClusters = append(diagnosis.MatchingClusters, cluster)
+		} else {
+			diagnosis.Reasons[cluster] = "job does not match cluster scheduling requirements"
 		}
 	}
-	return false
+
+	return diagnosis
 }
 
 func (server *SubmitServer) CancelJobs(ctx context.Context, request *api.JobCancelRequest) (*api.CancellationResult, error) {
@@ -152,6 +406,9 @@ func (server *SubmitServer) CancelJobs(ctx context.Context, request *api.JobCanc
 		if e != nil {
 			return nil, status.Errorf(codes.Internal, e.Error())
 		}
+		if len(jobs) == 0 {
+			return server.cancelScheduledJob(ctx, request.JobId)
+		}
 		return server.cancelJobs(ctx, jobs[0].Queue, jobs)
 	}
 
@@ -169,16 +426,58 @@ func (server *SubmitServer) CancelJobs(ctx context.Context, request *api.JobCanc
 	return nil, status.Errorf(codes.InvalidArgument, "Specify job id or queue with job set id")
 }
 
+// cancelScheduledJob cancels a job that was submitted with a future RunAt
+// and hasn't been activated yet, so it was never visible to
+// JobRepository.GetExistingJobsByIds.
+func (server *SubmitServer) cancelScheduledJob(ctx context.Context, jobId string) (*api.CancellationResult, error) {
+	job, e := server.scheduledJobRepository.GetScheduledJob(jobId)
+	if e != nil {
+		return nil, status.Errorf(codes.NotFound, e.Error())
+	}
+
+	if e := server.checkQueuePermission(ctx, job.Queue, permissions.CancelJobs, permissions.CancelAnyJobs); e != nil {
+		return nil, e
+	}
+
+	principal := authorization.GetPrincipal(ctx)
+	if e := admitCancellation(ctx, server.admissionControllers, principal, []*api.Job{job}); e != nil {
+		return nil, status.Errorf(codes.PermissionDenied, e.Error())
+	}
+
+	if e := server.scheduledJobRepository.RemoveScheduledJobs([]string{jobId}); e != nil {
+		return nil, status.Errorf(codes.Unknown, e.Error())
+	}
+
+	if _, e := server.jobHistoryRepository.RecordVersion(job, "Cancelled"); e != nil {
+		log.Errorf("Error recording history for cancelled job id %s: %s", job.Id, e.Error())
+	}
+
+	return &api.CancellationResult{[]string{jobId}}, nil
+}
+
 func (server *SubmitServer) cancelJobs(ctx context.Context, queue string, jobs []*api.Job) (*api.CancellationResult, error) {
 	if e := server.checkQueuePermission(ctx, queue, permissions.CancelJobs, permissions.CancelAnyJobs); e != nil {
 		return nil, e
 	}
 
+	principal := authorization.GetPrincipal(ctx)
+	if e := admitCancellation(ctx, server.admissionControllers, principal, jobs); e != nil {
+		return nil, status.Errorf(codes.PermissionDenied, e.Error())
+	}
+
 	e := reportJobsCancelling(server.eventStore, jobs)
 	if e != nil {
 		return nil, status.Errorf(codes.Unknown, e.Error())
 	}
 
+	jobIds := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		jobIds = append(jobIds, job.Id)
+	}
+	if e := server.scheduledJobRepository.RemoveScheduledJobs(jobIds); e != nil {
+		return nil, status.Errorf(codes.Unknown, e.Error())
+	}
+
 	deletionResult := server.jobRepository.DeleteJobs(jobs)
 	cancelled := []*api.Job{}
 	cancelledIds := []string{}
@@ -196,9 +495,92 @@ func (server *SubmitServer) cancelJobs(ctx context.Context, queue string, jobs [
 		return nil, status.Errorf(codes.Unknown, e.Error())
 	}
 
+	for _, job := range cancelled {
+		if _, e := server.jobHistoryRepository.RecordVersion(job, "Cancelled"); e != nil {
+			log.Errorf("Error recording history for cancelled job id %s: %s", job.Id, e.Error())
+		}
+	}
+
 	return &api.CancellationResult{cancelledIds}, nil
 }
 
+// GetJobVersions returns every recorded version of jobs in jobId's history,
+// oldest first, so operators can audit what was actually run.
+func (server *SubmitServer) GetJobVersions(ctx context.Context, req *api.JobHistoryRequest) (*api.JobHistoryResponse, error) {
+	if e := server.checkQueuePermission(ctx, req.Queue, permissions.ReadJobHistory, permissions.ReadAnyJobHistory); e != nil {
+		return nil, e
+	}
+
+	entries, e := server.jobHistoryRepository.GetJobVersions(req.Queue, req.JobId)
+	if e != nil {
+		return nil, status.Errorf(codes.Internal, e.Error())
+	}
+
+	response := &api.JobHistoryResponse{
+		JobVersions: make([]*api.JobHistoryItem, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		response.JobVersions = append(response.JobVersions, jobHistoryItemFromEntry(entry))
+	}
+	return response, nil
+}
+
+// GetJobAtVersion returns the job spec as it was recorded at a specific
+// version, for side-by-side comparison or resubmission.
+func (server *SubmitServer) GetJobAtVersion(ctx context.Context, req *api.JobAtVersionRequest) (*api.JobHistoryItem, error) {
+	entry, e := server.jobHistoryRepository.GetJobAtVersion(req.JobId, req.Version)
+	if e != nil {
+		return nil, status.Errorf(codes.NotFound, e.Error())
+	}
+
+	if e := server.checkQueuePermission(ctx, entry.Job.Queue, permissions.ReadJobHistory, permissions.ReadAnyJobHistory); e != nil {
+		return nil, e
+	}
+
+	return jobHistoryItemFromEntry(entry), nil
+}
+
+// ReSubmitJob resubmits the job spec recorded at a prior version verbatim,
+// going through the normal SubmitJobs path so the resubmission gets its own
+// history entry, events and scheduling validation.
+func (server *SubmitServer) ReSubmitJob(ctx context.Context, req *api.JobAtVersionRequest) (*api.JobSubmitResponse, error) {
+	entry, e := server.jobHistoryRepository.GetJobAtVersion(req.JobId, req.Version)
+	if e != nil {
+		return nil, status.Errorf(codes.NotFound, e.Error())
+	}
+
+	job := entry.Job
+	submitRequest := &api.JobSubmitRequest{
+		Queue:    job.Queue,
+		JobSetId: job.JobSetId,
+		JobRequestItems: []*api.JobSubmitRequestItem{
+			{
+				ClientId:           job.ClientId,
+				Priority:           job.Priority,
+				PodSpec:            job.PodSpec,
+				PodSpecs:           job.PodSpecs,
+				Namespace:          job.Namespace,
+				Labels:             job.Labels,
+				Annotations:        job.Annotations,
+				RequiredNodeLabels: job.RequiredNodeLabels,
+				Ingress:            job.Ingress,
+				Services:           job.Services,
+			},
+		},
+	}
+
+	return server.SubmitJobs(ctx, submitRequest)
+}
+
+func jobHistoryItemFromEntry(entry *repository.JobHistoryEntry) *api.JobHistoryItem {
+	return &api.JobHistoryItem{
+		JobId:   entry.JobId,
+		Version: entry.Version,
+		Job:     entry.Job,
+		Event:   entry.Event,
+	}
+}
+
 func (server *SubmitServer) checkQueuePermission(
 	ctx context.Context,
 	queueName string,
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/G-Research/armada/pkg/api"
+)
+
+func TestAttachRunAt(t *testing.T) {
+	runAt, e := types.TimestampProto(time.Now().Add(time.Hour))
+	if e != nil {
+		t.Fatalf("TimestampProto returned error: %s", e.Error())
+	}
+
+	req := &api.JobSubmitRequest{
+		JobRequestItems: []*api.JobSubmitRequestItem{
+			{RunAt: runAt},
+			{},
+		},
+	}
+	jobs := []*api.Job{{Id: "job-0"}, {Id: "job-1"}}
+
+	attachRunAt(req, jobs)
+
+	if jobs[0].RunAt != runAt {
+		t.Fatalf("expected job-0 to carry the request's RunAt")
+	}
+	if jobs[1].RunAt != nil {
+		t.Fatalf("expected job-1 to have no RunAt, got %v", jobs[1].RunAt)
+	}
+}
+
+func TestAttachRunAt_FewerRequestItemsThanJobs(t *testing.T) {
+	req := &api.JobSubmitRequest{JobRequestItems: []*api.JobSubmitRequestItem{{}}}
+	jobs := []*api.Job{{Id: "job-0"}, {Id: "job-1"}}
+
+	attachRunAt(req, jobs)
+}
+
+func TestSplitScheduledJobs(t *testing.T) {
+	future, e := types.TimestampProto(time.Now().Add(time.Hour))
+	if e != nil {
+		t.Fatalf("TimestampProto returned error: %s", e.Error())
+	}
+	past, e := types.TimestampProto(time.Now().Add(-time.Hour))
+	if e != nil {
+		t.Fatalf("TimestampProto returned error: %s", e.Error())
+	}
+
+	immediateJob := &api.Job{Id: "immediate"}
+	pastJob := &api.Job{Id: "past", RunAt: past}
+	futureJob := &api.Job{Id: "future", RunAt: future}
+
+	immediate, scheduled := splitScheduledJobs([]*api.Job{immediateJob, pastJob, futureJob})
+
+	if len(immediate) != 2 {
+		t.Fatalf("expected 2 immediate jobs, got %d", len(immediate))
+	}
+	if len(scheduled) != 1 || scheduled[0].Job.Id != "future" {
+		t.Fatalf("expected only the future job to be scheduled, got %+v", scheduled)
+	}
+}